@@ -0,0 +1,214 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Migration represents a request to live-migrate a pod's containers to a
+// different node. It is the durable, watchable record of progress that
+// replaces the previous ad-hoc kubelet HTTP handshake: clients create a
+// Migration and then watch status.phase/status.conditions instead of
+// blocking on an HTTP response.
+type Migration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MigrationSpec   `json:"spec,omitempty"`
+	Status MigrationStatus `json:"status,omitempty"`
+}
+
+// MigrationSpec describes the pod to migrate and where it should go.
+type MigrationSpec struct {
+	// PodRef identifies the pod being migrated. The pod must be running on
+	// a node reachable by the controller and must not be deleted while a
+	// Migration targeting it is in a non-terminal phase.
+	PodRef LocalPodReference `json:"podRef"`
+
+	// Containers restricts the migration to a subset of the pod's
+	// containers. An empty list means all containers in the pod.
+	// +optional
+	Containers []string `json:"containers,omitempty"`
+
+	// TargetNodeName is the node the pod's containers should be restored
+	// on. It must differ from the node the pod is currently running on.
+	TargetNodeName string `json:"targetNodeName"`
+
+	// Mode selects how memory is transferred to the target. Defaults to
+	// Full (a single stop-the-world checkpoint) if unset.
+	// +optional
+	Mode MigrationMode `json:"mode,omitempty"`
+
+	// MaxFinalBytes bounds PreCopy: once a pre-dump round's dirty-page
+	// delta is at or below this many bytes, the coordinator stops
+	// iterating and performs the final stop-the-world dump. Ignored
+	// outside PreCopy mode.
+	// +optional
+	MaxFinalBytes int64 `json:"maxFinalBytes,omitempty"`
+
+	// MaxIterations bounds PreCopy independently of MaxFinalBytes: the
+	// coordinator performs the final dump after this many pre-dump rounds
+	// regardless of the remaining dirty set. Ignored outside PreCopy mode.
+	// +optional
+	MaxIterations int32 `json:"maxIterations,omitempty"`
+}
+
+// MigrationMode selects the memory-transfer strategy used for a Migration.
+type MigrationMode string
+
+const (
+	// MigrationModeFull performs a single stop-the-world checkpoint, then
+	// transfers and restores it. This is the default and has the highest
+	// downtime but the simplest failure modes.
+	MigrationModeFull MigrationMode = "Full"
+	// MigrationModePreCopy iteratively pre-dumps the running container,
+	// transferring only the dirty pages each round, before a final short
+	// stop-the-world dump. This minimizes downtime at the cost of total
+	// migration time and network traffic.
+	MigrationModePreCopy MigrationMode = "PreCopy"
+	// MigrationModePostCopy transfers the minimum state needed to resume
+	// on the target immediately, lazily fetching remaining pages via
+	// userfaultfd. This minimizes time-to-resume at the cost of a window
+	// where the restored container depends on the source being reachable.
+	MigrationModePostCopy MigrationMode = "PostCopy"
+)
+
+// PreCopyRoundStatus records the outcome of one PreCopy pre-dump round.
+type PreCopyRoundStatus struct {
+	// Round is the 1-indexed round number; the final stop-the-world dump
+	// is not counted as a round.
+	Round int32 `json:"round"`
+	// Bytes is the size of the dirty-page delta transferred this round.
+	Bytes int64 `json:"bytes"`
+	// DirtyPages is the number of memory pages CRIU reported dirty this
+	// round.
+	DirtyPages int64 `json:"dirtyPages"`
+	// DurationSeconds is how long the round's dump+transfer took.
+	DurationSeconds float64 `json:"durationSeconds"`
+}
+
+// LocalPodReference identifies a pod within the Migration's own namespace.
+type LocalPodReference struct {
+	Name string `json:"name"`
+	UID  string `json:"uid,omitempty"`
+}
+
+// MigrationPhase is the high-level state of a Migration's state machine.
+type MigrationPhase string
+
+const (
+	// MigrationPending means the Migration has been accepted but the
+	// controller has not yet started acting on it.
+	MigrationPending MigrationPhase = "Pending"
+	// MigrationPreparing means the controller is validating the request
+	// and signalling the source kubelet to get ready (e.g. pausing
+	// readiness probes, resolving the prepare function).
+	MigrationPreparing MigrationPhase = "Preparing"
+	// MigrationCheckpointing means the source kubelet is capturing
+	// container state (CRIU dump, filesystem diff, etc).
+	MigrationCheckpointing MigrationPhase = "Checkpointing"
+	// MigrationTransferring means checkpoint artifacts are being streamed
+	// from the source node to the target node.
+	MigrationTransferring MigrationPhase = "Transferring"
+	// MigrationRestoring means the target kubelet is restoring the
+	// containers from the transferred checkpoint.
+	MigrationRestoring MigrationPhase = "Restoring"
+	// MigrationSucceeded is a terminal phase: the pod is running on the
+	// target node and the source has been torn down.
+	MigrationSucceeded MigrationPhase = "Succeeded"
+	// MigrationFailed is a terminal phase: the migration could not
+	// complete and the source pod was left running (or restarted).
+	MigrationFailed MigrationPhase = "Failed"
+)
+
+// MigrationConditionType is a well-known condition reported on a Migration.
+type MigrationConditionType string
+
+const (
+	// MigrationConditionSourceReady is set once the source kubelet has
+	// checkpointed the containers and is ready to transfer them.
+	MigrationConditionSourceReady MigrationConditionType = "SourceReady"
+	// MigrationConditionTargetReady is set once the target kubelet has
+	// confirmed the containers were restored, together with the Succeeded
+	// phase.
+	MigrationConditionTargetReady MigrationConditionType = "TargetReady"
+)
+
+// MigrationCondition is a timestamped observation of one aspect of a
+// Migration's progress, following the same shape as other Kubernetes
+// condition types (e.g. PodCondition, NodeCondition).
+type MigrationCondition struct {
+	Type               MigrationConditionType `json:"type"`
+	Status             ConditionStatus        `json:"status"`
+	LastTransitionTime metav1.Time            `json:"lastTransitionTime,omitempty"`
+	Reason             string                 `json:"reason,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+}
+
+// ConditionStatus mirrors v1.ConditionStatus without importing the core API
+// group, keeping this type buildable against a vendored core/v1 of any
+// supported skew.
+type ConditionStatus string
+
+const (
+	ConditionTrue    ConditionStatus = "True"
+	ConditionFalse   ConditionStatus = "False"
+	ConditionUnknown ConditionStatus = "Unknown"
+)
+
+// MigrationStatus reports the current phase and history of a Migration.
+type MigrationStatus struct {
+	// Phase is the current step of the migration state machine. Phase
+	// transitions are monotonic: the controller never moves a Migration
+	// backwards through the state machine.
+	// +optional
+	Phase MigrationPhase `json:"phase,omitempty"`
+
+	// Conditions holds the latest observations of the Migration's state.
+	// +optional
+	Conditions []MigrationCondition `json:"conditions,omitempty"`
+
+	// Containers maps container names to per-container status. The
+	// kubelet's migration manager does not write this field; it returns
+	// per-container archive paths and digests in its Result instead.
+	// +optional
+	Containers map[string]ContainerMigrationStatus `json:"containers,omitempty"`
+
+	// PreCopyRounds records per-round statistics for a PreCopy migration,
+	// in round order, so operators can tune MaxFinalBytes/MaxIterations.
+	// Empty outside PreCopy mode.
+	// +optional
+	PreCopyRounds []PreCopyRoundStatus `json:"preCopyRounds,omitempty"`
+}
+
+// ContainerMigrationStatus is the per-container projection of Status.
+type ContainerMigrationStatus struct {
+	CheckpointPath string `json:"checkpointPath,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MigrationList is a list of Migration objects.
+type MigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Migration `json:"items"`
+}
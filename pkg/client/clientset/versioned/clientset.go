@@ -0,0 +1,57 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package versioned is a minimal, hand-maintained stand-in for the
+// client-gen output the migration.k8s.io group will get once it leaves
+// staging. It intentionally only wires up the one typed client the
+// migration controller needs.
+package versioned
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/rest"
+	migrationv1alpha1 "k8s.io/kubernetes/pkg/client/clientset/versioned/typed/migration/v1alpha1"
+)
+
+// Interface is the set of typed clients exposed by this clientset.
+type Interface interface {
+	MigrationV1alpha1() migrationv1alpha1.MigrationV1alpha1Interface
+}
+
+// Clientset contains the clients for each API group.
+type Clientset struct {
+	migrationV1alpha1 *migrationv1alpha1.MigrationV1alpha1Client
+}
+
+// MigrationV1alpha1 retrieves the MigrationV1alpha1Client.
+func (c *Clientset) MigrationV1alpha1() migrationv1alpha1.MigrationV1alpha1Interface {
+	return c.migrationV1alpha1
+}
+
+var _ Interface = &Clientset{}
+
+// NewForConfig creates a new Clientset for the given config.
+func NewForConfig(c *rest.Config) (*Clientset, error) {
+	configShallowCopy := *c
+	cs := &Clientset{}
+	var err error
+	cs.migrationV1alpha1, err = migrationv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, fmt.Errorf("building migration.k8s.io/v1alpha1 client: %w", err)
+	}
+	return cs, nil
+}
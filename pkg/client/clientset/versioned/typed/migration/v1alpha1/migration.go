@@ -0,0 +1,139 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 is a hand-maintained typed client for the migration.k8s.io
+// API group, mirroring the shape client-gen would produce once this API
+// graduates out of staging.
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+	migrationv1alpha1 "k8s.io/kubernetes/pkg/apis/migration/v1alpha1"
+)
+
+// MigrationsGetter has a method to return a MigrationInterface.
+type MigrationsGetter interface {
+	Migrations(namespace string) MigrationInterface
+}
+
+// MigrationInterface has methods to work with Migration resources.
+type MigrationInterface interface {
+	Create(ctx context.Context, migration *migrationv1alpha1.Migration, opts metav1.CreateOptions) (*migrationv1alpha1.Migration, error)
+	Update(ctx context.Context, migration *migrationv1alpha1.Migration, opts metav1.UpdateOptions) (*migrationv1alpha1.Migration, error)
+	UpdateStatus(ctx context.Context, migration *migrationv1alpha1.Migration, opts metav1.UpdateOptions) (*migrationv1alpha1.Migration, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*migrationv1alpha1.Migration, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*migrationv1alpha1.MigrationList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+}
+
+type migrations struct {
+	client rest.Interface
+	ns     string
+}
+
+// newMigrations returns a Migrations.
+func newMigrations(c *MigrationV1alpha1Client, namespace string) *migrations {
+	return &migrations{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+func (c *migrations) Get(ctx context.Context, name string, opts metav1.GetOptions) (result *migrationv1alpha1.Migration, err error) {
+	result = &migrationv1alpha1.Migration{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("migrations").
+		Name(name).
+		VersionedParams(&opts, metav1.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *migrations) List(ctx context.Context, opts metav1.ListOptions) (result *migrationv1alpha1.MigrationList, err error) {
+	result = &migrationv1alpha1.MigrationList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("migrations").
+		VersionedParams(&opts, metav1.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *migrations) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("migrations").
+		VersionedParams(&opts, metav1.ParameterCodec).
+		Watch(ctx)
+}
+
+func (c *migrations) Create(ctx context.Context, migration *migrationv1alpha1.Migration, opts metav1.CreateOptions) (result *migrationv1alpha1.Migration, err error) {
+	result = &migrationv1alpha1.Migration{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("migrations").
+		VersionedParams(&opts, metav1.ParameterCodec).
+		Body(migration).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *migrations) Update(ctx context.Context, migration *migrationv1alpha1.Migration, opts metav1.UpdateOptions) (result *migrationv1alpha1.Migration, err error) {
+	result = &migrationv1alpha1.Migration{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("migrations").
+		Name(migration.Name).
+		VersionedParams(&opts, metav1.ParameterCodec).
+		Body(migration).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *migrations) UpdateStatus(ctx context.Context, migration *migrationv1alpha1.Migration, opts metav1.UpdateOptions) (result *migrationv1alpha1.Migration, err error) {
+	result = &migrationv1alpha1.Migration{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("migrations").
+		Name(migration.Name).
+		SubResource("status").
+		VersionedParams(&opts, metav1.ParameterCodec).
+		Body(migration).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *migrations) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("migrations").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
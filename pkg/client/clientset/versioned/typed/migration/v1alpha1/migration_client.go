@@ -0,0 +1,73 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/rest"
+	"k8s.io/kubernetes/pkg/client/clientset/versioned/scheme"
+
+	migrationv1alpha1 "k8s.io/kubernetes/pkg/apis/migration/v1alpha1"
+)
+
+// MigrationV1alpha1Interface has a method to return a MigrationV1alpha1Client.
+type MigrationV1alpha1Interface interface {
+	RESTClient() rest.Interface
+	MigrationsGetter
+}
+
+// MigrationV1alpha1Client is used to interact with features provided by the migration.k8s.io group.
+type MigrationV1alpha1Client struct {
+	restClient rest.Interface
+}
+
+func (c *MigrationV1alpha1Client) Migrations(namespace string) MigrationInterface {
+	return newMigrations(c, namespace)
+}
+
+// NewForConfig creates a new MigrationV1alpha1Client for the given config.
+func NewForConfig(c *rest.Config) (*MigrationV1alpha1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	client, err := rest.RESTClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &MigrationV1alpha1Client{restClient: client}, nil
+}
+
+func setConfigDefaults(config *rest.Config) error {
+	gv := migrationv1alpha1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = serializer.WithoutConversionCodecFactory{CodecFactory: scheme.Codecs}
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+	return nil
+}
+
+// RESTClient returns a RESTClient that is used to communicate with API server
+// by this client implementation.
+func (c *MigrationV1alpha1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
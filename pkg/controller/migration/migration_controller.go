@@ -0,0 +1,195 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package migration watches Migration objects and garbage-collects the
+// ones that have finished. The source and target kubelets
+// (pkg/kubelet/migration) are the sole, authoritative writers of
+// status.phase and status.conditions: Manager's TriggerPodMigration
+// advances a Migration through every phase itself via direct UpdateStatus
+// calls as it prepares, checkpoints, transfers, and restores the pod. This
+// controller does not also advance status.phase — two independent phase
+// drivers racing to UpdateStatus the same object would corrupt whichever
+// write lost the race. Its own job is cluster-level: once a Migration has
+// sat in a terminal phase (Succeeded/Failed) longer than
+// terminalMigrationTTL, delete it, the same way the built-in
+// ttl-after-finished controller reaps finished Jobs.
+package migration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	migrationv1alpha1 "k8s.io/kubernetes/pkg/apis/migration/v1alpha1"
+	clientset "k8s.io/kubernetes/pkg/client/clientset/versioned"
+)
+
+// terminalMigrationTTL is how long a Migration is kept around after
+// reaching a terminal phase before the controller garbage-collects it.
+// Operators and dashboards that want to inspect a finished Migration need
+// a window to do so, but nothing should rely on one existing forever.
+const terminalMigrationTTL = 24 * time.Hour
+
+// Controller watches Migration objects and garbage-collects ones that have
+// been terminal for longer than terminalMigrationTTL. See the package doc
+// comment: it does not drive status.phase, that's the kubelet's job.
+type Controller struct {
+	client clientset.Interface
+
+	informer cache.SharedIndexInformer
+	queue    workqueue.RateLimitingInterface
+
+	// now is overridden in tests; defaults to time.Now.
+	now func() time.Time
+}
+
+// NewController builds a Migration controller backed by the given client.
+func NewController(client clientset.Interface) *Controller {
+	lw := cache.NewListWatchFromClient(
+		client.MigrationV1alpha1().RESTClient(),
+		"migrations",
+		metav1.NamespaceAll,
+		fields.Everything(),
+	)
+
+	c := &Controller{
+		client: client,
+		queue:  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "migration"),
+		now:    time.Now,
+	}
+
+	c.informer = cache.NewSharedIndexInformer(lw, &migrationv1alpha1.Migration{}, 0, cache.Indexers{})
+	c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueue,
+		UpdateFunc: func(_, new interface{}) { c.enqueue(new) },
+	})
+
+	return c
+}
+
+func (c *Controller) enqueue(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+// Run starts the controller's informer and worker loop, blocking until
+// stopCh is closed.
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	go c.informer.Run(stopCh)
+	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced) {
+		runtime.HandleError(fmt.Errorf("migration controller: timed out waiting for cache sync"))
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+func (c *Controller) runWorker() {
+	for c.processNextItem() {
+	}
+}
+
+func (c *Controller) processNextItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.sync(key.(string)); err != nil {
+		runtime.HandleError(fmt.Errorf("syncing migration %q: %w", key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+// sync garbage-collects mig once it has been terminal for longer than
+// terminalMigrationTTL, deleting it outright. It deliberately never calls
+// UpdateStatus: see the package doc comment for why the kubelet, not this
+// controller, owns status.phase. A Migration not yet due for collection is
+// re-queued for the moment it will be, since nothing else will wake the
+// controller for it — the object itself isn't changing anymore.
+func (c *Controller) sync(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	mig, err := c.client.MigrationV1alpha1().Migrations(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	klog.V(4).Infof("Migration %s/%s observed in phase %s", mig.Namespace, mig.Name, mig.Status.Phase)
+
+	if !isTerminal(mig.Status.Phase) {
+		return nil
+	}
+
+	age := c.now().Sub(terminalSince(mig))
+	if remaining := terminalMigrationTTL - age; remaining > 0 {
+		c.queue.AddAfter(key, remaining)
+		return nil
+	}
+
+	if err := c.client.MigrationV1alpha1().Migrations(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	klog.V(2).Infof("garbage-collected Migration %s/%s, terminal for %s", mig.Namespace, mig.Name, age.Round(time.Second))
+	return nil
+}
+
+func isTerminal(phase migrationv1alpha1.MigrationPhase) bool {
+	return phase == migrationv1alpha1.MigrationSucceeded || phase == migrationv1alpha1.MigrationFailed
+}
+
+// terminalSince returns the time mig last transitioned condition state, as
+// the best available proxy for when it became terminal; a Migration with no
+// conditions yet (e.g. one that failed before the kubelet ever reported
+// SourceReady) falls back to its creation time.
+func terminalSince(mig *migrationv1alpha1.Migration) time.Time {
+	latest := mig.CreationTimestamp.Time
+	for _, cond := range mig.Status.Conditions {
+		if cond.LastTransitionTime.Time.After(latest) {
+			latest = cond.LastTransitionTime.Time
+		}
+	}
+	return latest
+}
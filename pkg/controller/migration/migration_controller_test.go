@@ -0,0 +1,76 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	migrationv1alpha1 "k8s.io/kubernetes/pkg/apis/migration/v1alpha1"
+)
+
+func TestIsTerminal(t *testing.T) {
+	cases := []struct {
+		phase migrationv1alpha1.MigrationPhase
+		want  bool
+	}{
+		{migrationv1alpha1.MigrationPending, false},
+		{migrationv1alpha1.MigrationPreparing, false},
+		{migrationv1alpha1.MigrationCheckpointing, false},
+		{migrationv1alpha1.MigrationTransferring, false},
+		{migrationv1alpha1.MigrationRestoring, false},
+		{migrationv1alpha1.MigrationSucceeded, true},
+		{migrationv1alpha1.MigrationFailed, true},
+	}
+
+	for _, tc := range cases {
+		if got := isTerminal(tc.phase); got != tc.want {
+			t.Errorf("isTerminal(%q) = %v, want %v", tc.phase, got, tc.want)
+		}
+	}
+}
+
+func TestTerminalSince(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := created.Add(time.Hour)
+
+	t.Run("falls back to creation time with no conditions", func(t *testing.T) {
+		mig := &migrationv1alpha1.Migration{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+		}
+		if got := terminalSince(mig); !got.Equal(created) {
+			t.Fatalf("terminalSince() = %v, want %v", got, created)
+		}
+	})
+
+	t.Run("uses the latest condition transition", func(t *testing.T) {
+		mig := &migrationv1alpha1.Migration{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+			Status: migrationv1alpha1.MigrationStatus{
+				Conditions: []migrationv1alpha1.MigrationCondition{
+					{Type: migrationv1alpha1.MigrationConditionSourceReady, LastTransitionTime: metav1.NewTime(created)},
+					{Type: migrationv1alpha1.MigrationConditionTargetReady, LastTransitionTime: metav1.NewTime(later)},
+				},
+			},
+		}
+		if got := terminalSince(mig); !got.Equal(later) {
+			t.Fatalf("terminalSince() = %v, want %v", got, later)
+		}
+	})
+}
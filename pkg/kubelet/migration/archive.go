@@ -0,0 +1,290 @@
+package migration
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/kubernetes/pkg/version"
+)
+
+const (
+	checkpointManifestName = "checkpoint.yaml"
+	configDumpName         = "config.json"
+	specDumpName           = "spec.dump"
+	rootfsDiffName         = "rootfs-diff.tar"
+	checkpointDirName      = "checkpoint"
+)
+
+// CheckpointManifest records the provenance and per-member integrity
+// digests of a checkpoint archive, the kubelet equivalent of podman's
+// checkpoint.yaml.
+type CheckpointManifest struct {
+	PodUID         string            `yaml:"podUID"`
+	ContainerName  string            `yaml:"containerName"`
+	KubeletVersion string            `yaml:"kubeletVersion"`
+	CRIUVersion    string            `yaml:"criuVersion"`
+	Digests        map[string]string `yaml:"digests"`
+}
+
+// ContainerSpecProvider returns a config.json-style snapshot of a
+// container's runtime spec. It is implemented by kubelet/container.
+type ContainerSpecProvider interface {
+	ContainerConfigDump(podUID, containerName string) ([]byte, error)
+}
+
+// RootfsDiffer produces a container's writable-layer delta as a tar
+// stream. It is implemented by kubelet/container.
+type RootfsDiffer interface {
+	RootfsDiff(podUID, containerName string) ([]byte, error)
+}
+
+// kubeletSpecDump is the kubelet-level state recorded alongside a
+// container's checkpoint: enough to recreate volume mounts and recognize
+// which secrets were in scope without embedding their contents.
+type kubeletSpecDump struct {
+	VolumeMounts []v1.VolumeMount  `json:"volumeMounts,omitempty"`
+	SecretHashes map[string]string `json:"secretHashes,omitempty"`
+	PodLabels    map[string]string `json:"podLabels,omitempty"`
+}
+
+// buildContainerArchive packages the CRIU dump the runtime wrote to
+// mig.path/containerName, together with a runtime config snapshot, a
+// rootfs diff, and kubelet-level state, into a single tar.gz archive with
+// a checkpoint.yaml manifest. It returns the archive's path and its
+// SHA-256 digest.
+func (m *manager) buildContainerArchive(pod *v1.Pod, mig *migration, containerName string) (archivePath string, digest string, err error) {
+	criuDir := path.Join(mig.path, containerName)
+	archivePath = criuDir + ".tar.gz"
+
+	f, err := os.Create(archivePath)
+	if err != nil {
+		return "", "", err
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	gzw := gzip.NewWriter(io.MultiWriter(f, hasher))
+	tw := tar.NewWriter(gzw)
+
+	digests := map[string]string{}
+
+	if m.specProvider != nil {
+		cfg, err := m.specProvider.ContainerConfigDump(string(pod.UID), containerName)
+		if err != nil {
+			return "", "", fmt.Errorf("config.json for %s: %w", containerName, err)
+		}
+		if err := writeArchiveMember(tw, configDumpName, cfg, digests); err != nil {
+			return "", "", err
+		}
+	}
+
+	specDump, err := json.Marshal(buildKubeletSpecDump(pod, containerName))
+	if err != nil {
+		return "", "", err
+	}
+	if err := writeArchiveMember(tw, specDumpName, specDump, digests); err != nil {
+		return "", "", err
+	}
+
+	if m.rootfsDiffer != nil {
+		diff, err := m.rootfsDiffer.RootfsDiff(string(pod.UID), containerName)
+		if err != nil {
+			return "", "", fmt.Errorf("rootfs diff for %s: %w", containerName, err)
+		}
+		if err := writeArchiveMember(tw, rootfsDiffName, diff, digests); err != nil {
+			return "", "", err
+		}
+	}
+
+	if err := writeArchiveDir(tw, checkpointDirName, criuDir, digests); err != nil {
+		return "", "", err
+	}
+
+	manifest := CheckpointManifest{
+		PodUID:         string(pod.UID),
+		ContainerName:  containerName,
+		KubeletVersion: version.Get().GitVersion,
+		CRIUVersion:    criuVersion(),
+		Digests:        digests,
+	}
+	manifestBytes, err := yaml.Marshal(manifest)
+	if err != nil {
+		return "", "", err
+	}
+	if err := writeTarHeaderAndBody(tw, checkpointManifestName, manifestBytes); err != nil {
+		return "", "", err
+	}
+
+	if err := tw.Close(); err != nil {
+		return "", "", err
+	}
+	if err := gzw.Close(); err != nil {
+		return "", "", err
+	}
+
+	return archivePath, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func buildKubeletSpecDump(pod *v1.Pod, containerName string) kubeletSpecDump {
+	dump := kubeletSpecDump{
+		SecretHashes: map[string]string{},
+		PodLabels:    pod.Labels,
+	}
+
+	for _, c := range pod.Spec.Containers {
+		if c.Name == containerName {
+			dump.VolumeMounts = c.VolumeMounts
+			break
+		}
+	}
+
+	for _, vol := range pod.Spec.Volumes {
+		if vol.Secret == nil {
+			continue
+		}
+		sum := sha256.Sum256([]byte(vol.Secret.SecretName))
+		dump.SecretHashes[vol.Secret.SecretName] = hex.EncodeToString(sum[:])
+	}
+
+	return dump
+}
+
+func criuVersion() string {
+	out, err := exec.Command("criu", "-V").Output()
+	if err != nil {
+		return "unknown"
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// writeArchiveMember writes data as a single regular-file tar entry named
+// name and records its digest.
+func writeArchiveMember(tw *tar.Writer, name string, data []byte, digests map[string]string) error {
+	if err := writeTarHeaderAndBody(tw, name, data); err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	digests[name] = hex.EncodeToString(sum[:])
+	return nil
+}
+
+func writeTarHeaderAndBody(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data))}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// writeArchiveDir copies every regular file under dir into the archive
+// under prefix/, recording a digest for each one.
+func writeArchiveDir(tw *tar.Writer, prefix, dir string, digests map[string]string) error {
+	return filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		name := path.Join(prefix, filepath.ToSlash(rel))
+		return writeArchiveMember(tw, name, data, digests)
+	})
+}
+
+// safeJoin joins destDir and an archive entry's name, rejecting names that
+// would resolve outside destDir (a "tar slip", e.g. "../../etc/cron.d/x")
+// before the caller ever opens the resulting path for writing. An absolute
+// name is not rejected: it is joined under destDir like a relative one.
+func safeJoin(destDir, name string) (string, error) {
+	target := filepath.Join(destDir, name)
+	cleanDest := filepath.Clean(destDir)
+	if target != cleanDest && !strings.HasPrefix(target, cleanDest+string(filepath.Separator)) {
+		return "", fmt.Errorf("archive entry %q escapes destination directory", name)
+	}
+	return target, nil
+}
+
+// extractArchive unpacks a checkpoint tar.gz built by buildContainerArchive
+// into destDir, returning the SHA-256 digest of the raw archive bytes as
+// read from disk so callers can re-verify it against the digest recorded
+// at transfer time.
+func extractArchive(archivePath, destDir string) (digest string, err error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	gzr, err := gzip.NewReader(io.TeeReader(f, hasher))
+	if err != nil {
+		return "", err
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		target, err := safeJoin(destDir, hdr.Name)
+		if err != nil {
+			return "", err
+		}
+		if hdr.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, os.FileMode(0777)); err != nil {
+				return "", err
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), os.FileMode(0777)); err != nil {
+			return "", err
+		}
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(hdr.Mode))
+		if err != nil {
+			return "", err
+		}
+		if _, err := io.Copy(out, tr); err != nil {
+			out.Close()
+			return "", err
+		}
+		out.Close()
+	}
+
+	// Hash whatever gzip left unread past the last tar entry so the digest
+	// covers the whole file.
+	io.Copy(hasher, f)
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -0,0 +1,109 @@
+package migration
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSafeJoin(t *testing.T) {
+	destDir := "/var/lib/kubelet/migration/pod-uid"
+
+	cases := []struct {
+		name    string
+		entry   string
+		wantErr bool
+	}{
+		{name: "plain file", entry: "checkpoint.yaml", wantErr: false},
+		{name: "nested file", entry: "checkpoint/pages-1.img", wantErr: false},
+		{name: "parent traversal", entry: "../evil.txt", wantErr: true},
+		{name: "deep traversal escapes destDir", entry: "../../../etc/cron.d/x", wantErr: true},
+		{name: "traversal that stays under destDir is fine", entry: "checkpoint/../checkpoint.yaml", wantErr: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			target, err := safeJoin(destDir, tc.entry)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("safeJoin(%q, %q) = %q, nil; want error", destDir, tc.entry, target)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("safeJoin(%q, %q) returned unexpected error: %v", destDir, tc.entry, err)
+			}
+			if !strings.HasPrefix(target, destDir) {
+				t.Fatalf("safeJoin(%q, %q) = %q, want path under %q", destDir, tc.entry, target, destDir)
+			}
+		})
+	}
+}
+
+// buildTestArchive writes a tar.gz to path containing a single entry named
+// entryName with the given contents, bypassing buildContainerArchive so a
+// malicious (e.g. path-traversing) entry name can be tested.
+func buildTestArchive(t *testing.T, archivePath, entryName string, contents []byte) {
+	t.Helper()
+
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf("creating test archive: %v", err)
+	}
+	defer f.Close()
+
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+
+	if err := tw.WriteHeader(&tar.Header{Name: entryName, Mode: 0644, Size: int64(len(contents))}); err != nil {
+		t.Fatalf("writing tar header: %v", err)
+	}
+	if _, err := tw.Write(contents); err != nil {
+		t.Fatalf("writing tar body: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("closing tar writer: %v", err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("closing gzip writer: %v", err)
+	}
+}
+
+func TestExtractArchiveRejectsTarSlip(t *testing.T) {
+	root := t.TempDir()
+	archivePath := filepath.Join(root, "checkpoint.tar.gz")
+	destDir := filepath.Join(root, "dest")
+
+	buildTestArchive(t, archivePath, "../escaped.txt", []byte("not supposed to land here"))
+
+	if _, err := extractArchive(archivePath, destDir); err == nil {
+		t.Fatal("extractArchive of a tar-slip archive succeeded, want error")
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "escaped.txt")); !os.IsNotExist(err) {
+		t.Fatalf("tar-slip entry was written outside destDir: stat err = %v", err)
+	}
+}
+
+func TestExtractArchiveWritesWellFormedEntries(t *testing.T) {
+	root := t.TempDir()
+	archivePath := filepath.Join(root, "checkpoint.tar.gz")
+	destDir := filepath.Join(root, "dest")
+
+	buildTestArchive(t, archivePath, "checkpoint.yaml", []byte("podUID: abc"))
+
+	if _, err := extractArchive(archivePath, destDir); err != nil {
+		t.Fatalf("extractArchive returned unexpected error: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(destDir, "checkpoint.yaml"))
+	if err != nil {
+		t.Fatalf("reading extracted entry: %v", err)
+	}
+	if string(got) != "podUID: abc" {
+		t.Fatalf("extracted entry content = %q, want %q", got, "podUID: abc")
+	}
+}
@@ -0,0 +1,96 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+)
+
+// AuthInterface is the subset of the kubelet's server.AuthInterface (the
+// same interface guarding /exec and /logs) HandleMigrationRequest needs:
+// authenticate the caller, then authorize them for verb "migrate" on
+// pods/migration in the pod's namespace. A Manager constructed without one
+// (e.g. in tests) skips authn/authz entirely.
+type AuthInterface interface {
+	authenticator.Request
+	authorizer.Authorizer
+}
+
+// migrationError is the structured error body HandleMigrationRequest
+// returns for authn/authz/validation failures, consistent with the rest of
+// the kubelet's HTTP API.
+type migrationError struct {
+	Code    int    `json:"code"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+func writeMigrationError(res *restful.Response, code int, reason, message string) {
+	if err := res.WriteHeaderAndJson(code, migrationError{Code: code, Reason: reason, Message: message}, restful.MIME_JSON); err != nil {
+		res.WriteHeader(code)
+	}
+}
+
+// validateContainerNames rejects a containers query parameter that names
+// anything other than containers actually belonging to pod, closing off
+// the path-traversal vector of an attacker-controlled name reaching
+// path.Join(mig.path, name). An empty names means "all of pod's
+// containers" and is always valid.
+func validateContainerNames(pod *v1.Pod, names []string) error {
+	declared := make(map[string]bool, len(pod.Spec.Containers))
+	for _, c := range pod.Spec.Containers {
+		declared[c.Name] = true
+	}
+
+	for _, name := range names {
+		if err := validateContainerName(name); err != nil {
+			return err
+		}
+		if !declared[name] {
+			return fmt.Errorf("pod %s has no container named %q", pod.Name, name)
+		}
+	}
+	return nil
+}
+
+// validateContainerName rejects path-traversal and control characters in a
+// single container name before it is ever used to build a filesystem path.
+func validateContainerName(name string) error {
+	if name == "" {
+		return fmt.Errorf("container name must not be empty")
+	}
+	if strings.Contains(name, "/") || strings.Contains(name, "..") {
+		return fmt.Errorf("invalid container name %q", name)
+	}
+	for _, r := range name {
+		if r < 0x20 || r == 0x7f {
+			return fmt.Errorf("invalid container name %q", name)
+		}
+	}
+	return nil
+}
+
+// validatePodUID applies the same path-traversal and control-character
+// rejection as validateContainerName to a pod UID taken from a URL path
+// parameter, before it is used to build a filesystem path. Receiver trusts
+// callers to pass the real UID of an existing pod (authorize looks it up via
+// podManager), but the UID string itself still reaches path.Join unvalidated
+// ahead of that lookup, so it needs the same guard.
+func validatePodUID(uid string) error {
+	if uid == "" {
+		return fmt.Errorf("pod UID must not be empty")
+	}
+	if strings.Contains(uid, "/") || strings.Contains(uid, "..") {
+		return fmt.Errorf("invalid pod UID %q", uid)
+	}
+	for _, r := range uid {
+		if r < 0x20 || r == 0x7f {
+			return fmt.Errorf("invalid pod UID %q", uid)
+		}
+	}
+	return nil
+}
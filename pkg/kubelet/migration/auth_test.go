@@ -0,0 +1,92 @@
+package migration
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestValidateContainerName(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "valid", input: "app", wantErr: false},
+		{name: "empty", input: "", wantErr: true},
+		{name: "parent dir traversal", input: "../../etc", wantErr: true},
+		{name: "embedded traversal", input: "app/../../etc", wantErr: true},
+		{name: "path separator", input: "a/b", wantErr: true},
+		{name: "control character", input: "app\x00", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateContainerName(tc.input)
+			if tc.wantErr && err == nil {
+				t.Fatalf("validateContainerName(%q) = nil, want error", tc.input)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("validateContainerName(%q) = %v, want nil", tc.input, err)
+			}
+		})
+	}
+}
+
+func TestValidatePodUID(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "valid", input: "d9f1c2a0-1234-4abc-9def-0123456789ab", wantErr: false},
+		{name: "empty", input: "", wantErr: true},
+		{name: "parent dir traversal", input: "../../etc/cron.d/x", wantErr: true},
+		{name: "embedded traversal", input: "pod/../../etc", wantErr: true},
+		{name: "path separator", input: "a/b", wantErr: true},
+		{name: "control character", input: "pod\x00", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePodUID(tc.input)
+			if tc.wantErr && err == nil {
+				t.Fatalf("validatePodUID(%q) = nil, want error", tc.input)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("validatePodUID(%q) = %v, want nil", tc.input, err)
+			}
+		})
+	}
+}
+
+func TestValidateContainerNames(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "app"}, {Name: "sidecar"}},
+		},
+	}
+
+	cases := []struct {
+		name    string
+		input   []string
+		wantErr bool
+	}{
+		{name: "empty means all containers", input: nil, wantErr: false},
+		{name: "declared containers", input: []string{"app", "sidecar"}, wantErr: false},
+		{name: "undeclared container", input: []string{"app", "evil"}, wantErr: true},
+		{name: "path traversal never reaches the declared check", input: []string{"../../etc/cron.d/x"}, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateContainerNames(pod, tc.input)
+			if tc.wantErr && err == nil {
+				t.Fatalf("validateContainerNames(%v) = nil, want error", tc.input)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("validateContainerNames(%v) = %v, want nil", tc.input, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package migration
+
+import (
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+// migrationsInFlight tracks how many migrations this kubelet currently has
+// past admission (i.e. holding a MaxConcurrentMigrations slot), so an
+// operator can tell a stalled migration apart from a node that's simply
+// saturated.
+var migrationsInFlight = metrics.NewGauge(
+	&metrics.GaugeOpts{
+		Subsystem:      "kubelet",
+		Name:           "migrations_in_flight",
+		Help:           "Number of pod migrations currently being checkpointed, transferred, or restored by this kubelet.",
+		StabilityLevel: metrics.ALPHA,
+	},
+)
+
+func init() {
+	legacyregistry.MustRegister(migrationsInFlight)
+}
@@ -1,37 +1,102 @@
 package migration
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"path"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/emicklei/go-restful"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
 	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
+	migrationv1alpha1 "k8s.io/kubernetes/pkg/apis/migration/v1alpha1"
+	migrationclientset "k8s.io/kubernetes/pkg/client/clientset/versioned"
 	"k8s.io/kubernetes/pkg/kubelet/container"
 	kubepod "k8s.io/kubernetes/pkg/kubelet/pod"
 )
 
+// DefaultMaxConcurrentMigrations bounds how many migrations a manager runs
+// at once when NewManager is given a maxConcurrentMigrations <= 0.
+const DefaultMaxConcurrentMigrations = 2
+
+// MigrationQueueMode controls what happens to a migration request received
+// once MaxConcurrentMigrations are already in flight.
+type MigrationQueueMode string
+
+const (
+	// MigrationQueueBlock makes the caller wait for a free slot, subject to
+	// the request's context being cancelled.
+	MigrationQueueBlock MigrationQueueMode = "Block"
+	// MigrationQueueReject fails the request immediately with 429 Too Many
+	// Requests instead of waiting for a free slot.
+	MigrationQueueReject MigrationQueueMode = "Reject"
+)
+
+// errTooManyMigrations is returned by acquireMigrationSlot in
+// MigrationQueueReject mode once MaxConcurrentMigrations are in flight.
+var errTooManyMigrations = errors.New("too many migrations in flight")
+
 type Manager interface {
 	HandleMigrationRequest(*restful.Request, *restful.Response)
 	FindMigrationForPod(*v1.Pod) (Migration, bool)
-	TriggerPodMigration(*v1.Pod) (Result, error)
+	TriggerPodMigration(ctx context.Context, pod *v1.Pod) (Result, error)
+
+	// ValidateTargetResources fails fast, before any checkpoint is taken,
+	// if targetNode cannot satisfy the device-plugin resources pod's
+	// containers are currently holding.
+	ValidateTargetResources(pod *v1.Pod, targetNode string) error
+
+	// Run starts the Manager's watch over Migration objects targeting this
+	// node and blocks until stopCh is closed.
+	Run(stopCh <-chan struct{})
 }
 
 type Migration interface {
 	Options() *container.MigratePodOptions
-	WaitUntilFinished()
+	// WaitUntilFinished blocks until the source has decided the outcome
+	// of the handoff, returning true if it's safe to tear down the
+	// source container (restore on the target was confirmed, or there
+	// was never a target to confirm with) and false on any failure, so
+	// the runtime knows not to delete a pod it may still need.
+	WaitUntilFinished() bool
 }
 
-func NewManager(kubeClient clientset.Interface, podManager kubepod.Manager, prepareMigartionFn prepareMigrationFunc, rootPath string) Manager {
+func NewManager(kubeClient clientset.Interface, migrationClient migrationclientset.Interface, podManager kubepod.Manager, prepareMigartionFn prepareMigrationFunc, rootPath string, nodeName string, transferer *Transferer, specProvider ContainerSpecProvider, rootfsDiffer RootfsDiffer, deviceAssignments DeviceAssignmentProvider, maxConcurrentMigrations int, queueMode MigrationQueueMode, auth AuthInterface) Manager {
+	if maxConcurrentMigrations <= 0 {
+		maxConcurrentMigrations = DefaultMaxConcurrentMigrations
+	}
+	if queueMode == "" {
+		queueMode = MigrationQueueBlock
+	}
+
 	return &manager{
 		migrationPath:      path.Join(rootPath, "migration"),
+		nodeName:           nodeName,
 		kubeClient:         kubeClient,
+		migrationClient:    migrationClient,
 		podManager:         podManager,
 		prepareMigrationFn: prepareMigartionFn,
+		transferer:         transferer,
+		specProvider:       specProvider,
+		rootfsDiffer:       rootfsDiffer,
+		deviceAssignments:  deviceAssignments,
+		migrationQueueMode: queueMode,
+		migrationSem:       make(chan struct{}, maxConcurrentMigrations),
+		auth:               auth,
 		migrations:         make(map[types.UID]*migration),
 	}
 }
@@ -40,21 +105,54 @@ type prepareMigrationFunc func(*v1.Pod)
 
 type manager struct {
 	migrationPath string
+	nodeName      string
 
 	kubeClient         clientset.Interface
+	migrationClient    migrationclientset.Interface
 	podManager         kubepod.Manager
 	prepareMigrationFn prepareMigrationFunc
+	transferer         *Transferer
+	specProvider       ContainerSpecProvider
+	rootfsDiffer       RootfsDiffer
+	deviceAssignments  DeviceAssignmentProvider
+
+	migrationQueueMode MigrationQueueMode
+	migrationSem       chan struct{}
+
+	// auth authenticates and authorizes incoming HandleMigrationRequest
+	// calls, the same AuthInterface the kubelet server wires into /exec
+	// and /logs. Nil skips authn/authz (e.g. tests).
+	auth AuthInterface
 
-	migrations map[types.UID]*migration
+	migrationsLock sync.RWMutex
+	migrations     map[types.UID]*migration
 }
 
 var _ Manager = &manager{}
 
 type migration struct {
+	// name/namespace identify the Migration object backing this local
+	// bookkeeping entry, once one has been created or observed.
+	name      string
+	namespace string
+
 	path       string
 	containers []string
-	unblock    chan struct{}
-	done       chan struct{}
+	targetNode string
+
+	mode          MigrationMode
+	maxFinalBytes int64
+	maxIterations int32
+	// onPreCopyRound is invoked by the container runtime once per PreCopy
+	// pre-dump round; bound to this migration in newMigration so it can
+	// report progress without the runtime needing a manager reference.
+	onPreCopyRound PreCopyRoundFunc
+
+	// unblock carries the handoff's outcome to whatever is parked in
+	// WaitUntilFinished: true once it's safe to delete the source
+	// container, false on any failure. See TriggerPodMigration.
+	unblock chan bool
+	done    chan struct{}
 }
 
 type Result struct {
@@ -63,11 +161,27 @@ type Result struct {
 }
 
 type ResultContainer struct {
-	CheckpointPath string
+	// ArchivePath is the path of the self-contained checkpoint.yaml/
+	// config.json/checkpoint/rootfs-diff.tar/spec.dump tar.gz archive
+	// produced for this container, see archive.go.
+	ArchivePath string
+	// Digest is the SHA-256 digest of the archive at ArchivePath.
+	Digest string
+	// ResourceAssignments records the device-plugin resources (SR-IOV VFs,
+	// GPUs, hugepages, ...) allocated to this container, keyed by resource
+	// name, so the target node can recreate an equivalent allocation
+	// before CRIU restore.
+	ResourceAssignments map[string]ResourceAssignment
 }
 
 var _ Migration = &migration{}
 
+// HandleMigrationRequest is kept only as a thin compatibility shim over the
+// Migration CRD workflow: it translates the legacy HTTP request into a
+// Migration object and waits for the same state machine that
+// Run/TriggerPodMigration drive to reach a terminal phase, so existing
+// callers of this endpoint and callers of `kubectl get migrations` observe
+// the same underlying migration.
 func (m *manager) HandleMigrationRequest(req *restful.Request, res *restful.Response) {
 	params := getMigrationRequestParams(req)
 	klog.V(2).Infof("POST Migrate - %v %v", params.podUID, params.containerNames)
@@ -76,56 +190,355 @@ func (m *manager) HandleMigrationRequest(req *restful.Request, res *restful.Resp
 	var ok bool
 
 	if pod, ok = m.podManager.GetPodByUID(types.UID(params.podUID)); !ok {
-		res.WriteHeader(http.StatusNotFound)
+		writeMigrationError(res, http.StatusNotFound, "PodNotFound", "no pod found with the given UID")
 		return
 	}
 
 	if pod.Status.Phase != v1.PodRunning {
-		res.WriteHeader(http.StatusConflict)
+		writeMigrationError(res, http.StatusConflict, "PodNotRunning", "pod is not running")
+		return
+	}
+
+	if err := validateContainerNames(pod, params.containerNames); err != nil {
+		writeMigrationError(res, http.StatusBadRequest, "InvalidContainers", err.Error())
+		return
+	}
+
+	if m.auth != nil {
+		userInfo, authenticated, err := m.auth.AuthenticateRequest(req.Request)
+		if err != nil || !authenticated {
+			writeMigrationError(res, http.StatusUnauthorized, "Unauthorized", "request could not be authenticated")
+			return
+		}
+
+		attrs := authorizer.AttributesRecord{
+			User:            userInfo,
+			Verb:            "migrate",
+			Namespace:       pod.Namespace,
+			APIGroup:        "",
+			Resource:        "pods",
+			Subresource:     "migration",
+			Name:            pod.Name,
+			ResourceRequest: true,
+		}
+		decision, reason, err := m.auth.Authorize(req.Request.Context(), attrs)
+		if err != nil || decision != authorizer.DecisionAllow {
+			writeMigrationError(res, http.StatusForbidden, "Forbidden", fmt.Sprintf("not authorized to migrate pod %s: %s", pod.Name, reason))
+			return
+		}
+	}
+
+	cr := &migrationv1alpha1.Migration{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "migration-",
+			Namespace:    pod.Namespace,
+		},
+		Spec: migrationv1alpha1.MigrationSpec{
+			PodRef: migrationv1alpha1.LocalPodReference{
+				Name: pod.Name,
+				UID:  string(pod.UID),
+			},
+			Containers:     params.containerNames,
+			TargetNodeName: params.targetNodeName,
+			Mode:           params.mode,
+			MaxFinalBytes:  params.maxFinalBytes,
+			MaxIterations:  params.maxIterations,
+		},
+		Status: migrationv1alpha1.MigrationStatus{Phase: migrationv1alpha1.MigrationPending},
+	}
+
+	created, err := m.migrationClient.MigrationV1alpha1().Migrations(pod.Namespace).Create(context.TODO(), cr, metav1.CreateOptions{})
+	if err != nil {
+		klog.Errorf("failed to create Migration object for pod %s: %v", pod.Name, err)
+		writeMigrationError(res, http.StatusInternalServerError, "MigrationCreateFailed", err.Error())
 		return
 	}
 
 	mig := m.newMigration(pod)
 	mig.containers = params.containerNames
+	mig.targetNode = params.targetNodeName
+	mig.mode = params.mode
+	mig.maxFinalBytes = params.maxFinalBytes
+	mig.maxIterations = params.maxIterations
+	mig.name, mig.namespace = created.Name, created.Namespace
 	mig.EnsurePathExists()
 
-	klog.V(2).Infof("Starting migration of Pod %v", pod.Name)
-	m.prepareMigrationFn(pod)
-
-	<-mig.done
-	r := Result{Path: mig.path, Containers: map[string]ResultContainer{}}
-	for _, c := range mig.containers {
-		r.Containers[c] = ResultContainer{CheckpointPath: path.Join(mig.path, c)}
+	klog.V(2).Infof("Starting migration of Pod %v (Migration %s/%s)", pod.Name, mig.namespace, mig.name)
+	r, err := m.TriggerPodMigration(req.Request.Context(), pod)
+	if err != nil {
+		if errors.Is(err, errTooManyMigrations) {
+			writeMigrationError(res, http.StatusTooManyRequests, "TooManyMigrations", err.Error())
+			return
+		}
+		klog.Errorf("migration of pod %s failed: %v", pod.Name, err)
+		writeMigrationError(res, http.StatusInternalServerError, "MigrationFailed", err.Error())
+		return
 	}
+
 	if err := res.WriteAsJson(r); err != nil {
 		klog.Error("failed to encode migration result.", err)
 	}
 	res.WriteHeader(http.StatusOK)
-	mig.unblock <- struct{}{}
 }
 
 func (m *manager) FindMigrationForPod(pod *v1.Pod) (Migration, bool) {
+	m.migrationsLock.RLock()
+	defer m.migrationsLock.RUnlock()
 	mig, ok := m.migrations[pod.UID]
 	return mig, ok
 }
 
+// acquireMigrationSlot reserves one of MaxConcurrentMigrations slots so a
+// burst of migration requests can't checkpoint dozens of pods at once and
+// exhaust disk or network bandwidth. In MigrationQueueReject mode it fails
+// fast with errTooManyMigrations instead of queuing; otherwise it blocks
+// until a slot frees up or ctx is cancelled.
+func (m *manager) acquireMigrationSlot(ctx context.Context) error {
+	select {
+	case m.migrationSem <- struct{}{}:
+		migrationsInFlight.Inc()
+		return nil
+	default:
+	}
+
+	if m.migrationQueueMode == MigrationQueueReject {
+		return errTooManyMigrations
+	}
+
+	select {
+	case m.migrationSem <- struct{}{}:
+		migrationsInFlight.Inc()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *manager) releaseMigrationSlot() {
+	<-m.migrationSem
+	migrationsInFlight.Dec()
+}
+
+// TriggerPodMigration drives the source-node half of a pod's migration:
+// validating the target can take it, preparing the pod, waiting for the
+// runtime to checkpoint it, and recording every phase transition (plus
+// the condition that unblocks it) on the backing Migration object so
+// `kubectl get migrations` reflects live progress. It blocks (or fails
+// with errTooManyMigrations in MigrationQueueReject mode) until a
+// MaxConcurrentMigrations slot is free.
+func (m *manager) TriggerPodMigration(ctx context.Context, pod *v1.Pod) (Result, error) {
+	if err := m.acquireMigrationSlot(ctx); err != nil {
+		return Result{}, err
+	}
+	defer m.releaseMigrationSlot()
+
+	var localMig *migration
+	if mig, ok := m.FindMigrationForPod(pod); ok {
+		localMig = mig.(*migration)
+	} else {
+		localMig = m.newMigration(pod)
+		localMig.EnsurePathExists()
+	}
+
+	// Checked before the pod is even prepared/checkpointed, not just
+	// before the transfer, so a target that can't take the pod fails the
+	// migration without pausing the source for nothing.
+	if localMig.targetNode != "" {
+		if err := m.ValidateTargetResources(pod, localMig.targetNode); err != nil {
+			m.setPhase(localMig, migrationv1alpha1.MigrationFailed)
+			return Result{}, fmt.Errorf("target node %s cannot satisfy pod %s's resource requirements: %w", localMig.targetNode, pod.Name, err)
+		}
+	}
+
+	if err := m.setPhase(localMig, migrationv1alpha1.MigrationPreparing); err != nil {
+		return Result{}, err
+	}
+
+	m.prepareMigrationFn(pod)
+	<-localMig.done
+
+	// From here on the container runtime is blocked in WaitUntilFinished
+	// waiting for localMig.unblock to report whether it's safe to delete
+	// the source container. succeeded defaults to false, so every
+	// failure return between here and the end reports "don't delete it"
+	// instead of leaving the runtime waiting forever; the defer
+	// guarantees exactly one send regardless of which return fires, and
+	// the happy path below flips succeeded to true just before its own
+	// return. On failure the defer also marks the Migration Failed.
+	succeeded := false
+	defer func() {
+		if !succeeded {
+			m.setPhase(localMig, migrationv1alpha1.MigrationFailed)
+		}
+		localMig.unblock <- succeeded
+	}()
+
+	if err := m.setPhase(localMig, migrationv1alpha1.MigrationCheckpointing); err != nil {
+		return Result{}, err
+	}
+
+	r := Result{Path: localMig.path, Containers: map[string]ResultContainer{}}
+	for _, c := range localMig.containers {
+		archivePath, digest, err := m.buildContainerArchive(pod, localMig, c)
+		if err != nil {
+			return Result{}, fmt.Errorf("building checkpoint archive for %s: %w", c, err)
+		}
+		rc := ResultContainer{ArchivePath: archivePath, Digest: digest}
+
+		if m.deviceAssignments != nil {
+			assignments, err := m.deviceAssignments.ContainerResourceAssignments(string(pod.UID), c)
+			if err != nil {
+				return Result{}, fmt.Errorf("reading device assignments for %s: %w", c, err)
+			}
+			rc.ResourceAssignments = assignments
+		}
+
+		r.Containers[c] = rc
+	}
+
+	if err := m.setPhaseWithCondition(localMig, migrationv1alpha1.MigrationTransferring, migrationv1alpha1.MigrationConditionSourceReady); err != nil {
+		return Result{}, err
+	}
+
+	// Without a configured Transferer (e.g. tests, or a single-node
+	// setup) there's no target restore to wait on, so the Migration is
+	// marked Succeeded and succeeded is set as soon as the checkpoint
+	// itself is captured.
+	if m.transferer != nil && localMig.targetNode != "" {
+		if err := m.setPhase(localMig, migrationv1alpha1.MigrationRestoring); err != nil {
+			return Result{}, err
+		}
+		if err := m.transferer.TransferPod(localMig.targetNode, string(pod.UID), r); err != nil {
+			m.setPhase(localMig, migrationv1alpha1.MigrationFailed)
+			return Result{}, fmt.Errorf("transferring pod %s to %s: %w", pod.Name, localMig.targetNode, err)
+		}
+		if err := m.setPhaseWithCondition(localMig, migrationv1alpha1.MigrationSucceeded, migrationv1alpha1.MigrationConditionTargetReady); err != nil {
+			return Result{}, err
+		}
+	} else if err := m.setPhase(localMig, migrationv1alpha1.MigrationSucceeded); err != nil {
+		return Result{}, err
+	}
+
+	succeeded = true
+	return r, nil
+}
+
+// Run watches Migration objects and, for any whose source pod is running
+// on this node, drives it via TriggerPodMigration. This is how migrations
+// created directly (e.g. by a controller or `kubectl create -f`) reach the
+// kubelet, without going through the legacy HTTP endpoint.
+func (m *manager) Run(stopCh <-chan struct{}) {
+	lw := cache.NewListWatchFromClient(
+		m.migrationClient.MigrationV1alpha1().RESTClient(),
+		"migrations",
+		metav1.NamespaceAll,
+		fields.Everything(),
+	)
+	informer := cache.NewSharedInformer(lw, &migrationv1alpha1.Migration{}, 0)
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { m.handleMigrationEvent(obj) },
+	})
+
+	go informer.Run(stopCh)
+	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
+		runtime.HandleError(fmt.Errorf("migration manager: timed out waiting for cache sync"))
+	}
+	<-stopCh
+}
+
+func (m *manager) handleMigrationEvent(obj interface{}) {
+	mig, ok := obj.(*migrationv1alpha1.Migration)
+	if !ok {
+		return
+	}
+	if mig.Status.Phase != "" && mig.Status.Phase != migrationv1alpha1.MigrationPending {
+		return
+	}
+
+	pod, ok := m.podManager.GetPodByUID(types.UID(mig.Spec.PodRef.UID))
+	if !ok {
+		// Pod isn't (yet) known on this node: either it's the migration
+		// target, or it belongs to another node entirely.
+		return
+	}
+
+	if _, already := m.FindMigrationForPod(pod); already {
+		return
+	}
+
+	local := m.newMigration(pod)
+	local.name, local.namespace = mig.Name, mig.Namespace
+	local.containers = mig.Spec.Containers
+	local.targetNode = mig.Spec.TargetNodeName
+	local.mode = mig.Spec.Mode
+	local.maxFinalBytes = mig.Spec.MaxFinalBytes
+	local.maxIterations = mig.Spec.MaxIterations
+	local.EnsurePathExists()
+
+	if _, err := m.TriggerPodMigration(context.Background(), pod); err != nil {
+		klog.Errorf("migration %s/%s failed: %v", mig.Namespace, mig.Name, err)
+	}
+}
+
+func (m *manager) setPhase(mig *migration, phase migrationv1alpha1.MigrationPhase) error {
+	return m.setPhaseWithCondition(mig, phase, "")
+}
+
+func (m *manager) setPhaseWithCondition(mig *migration, phase migrationv1alpha1.MigrationPhase, condType migrationv1alpha1.MigrationConditionType) error {
+	if mig.name == "" {
+		// Not yet backed by a Migration object (e.g. tests constructing a
+		// bare manager); nothing to report.
+		return nil
+	}
+
+	cr, err := m.migrationClient.MigrationV1alpha1().Migrations(mig.namespace).Get(context.TODO(), mig.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cr.Status.Phase = phase
+	if condType != "" {
+		cr.Status.Conditions = append(cr.Status.Conditions, migrationv1alpha1.MigrationCondition{
+			Type:               condType,
+			Status:             migrationv1alpha1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+		})
+	}
+
+	_, err = m.migrationClient.MigrationV1alpha1().Migrations(mig.namespace).UpdateStatus(context.TODO(), cr, metav1.UpdateOptions{})
+	return err
+}
+
 func (m *manager) newMigration(pod *v1.Pod) *migration {
 	mig := &migration{
 		path:    path.Join(m.migrationPath, string(pod.UID)),
-		unblock: make(chan struct{}),
+		unblock: make(chan bool),
 		done:    make(chan struct{}),
 	}
+	mig.onPreCopyRound = func(round int32, bytes, dirtyPages int64, dur time.Duration) {
+		m.recordPreCopyRound(mig, round, bytes, dirtyPages, dur)
+	}
+
+	m.migrationsLock.Lock()
 	m.migrations[pod.GetUID()] = mig
+	m.migrationsLock.Unlock()
+
 	return mig
 }
 
 func (m *manager) removeMigration(pod *v1.Pod) {
+	m.migrationsLock.Lock()
 	mig, ok := m.migrations[pod.GetUID()]
+	delete(m.migrations, pod.GetUID())
+	m.migrationsLock.Unlock()
+
 	if !ok {
 		return
 	}
 	mig.done <- struct{}{}
-	delete(m.migrations, pod.GetUID())
 }
 
 func (mg *migration) Options() *container.MigratePodOptions {
@@ -135,11 +548,15 @@ func (mg *migration) Options() *container.MigratePodOptions {
 		Unblock:        mg.unblock,
 		Done:           mg.done,
 		Containers:     mg.containers,
+		Mode:           container.MigrationMode(mg.mode),
+		MaxFinalBytes:  mg.maxFinalBytes,
+		MaxIterations:  mg.maxIterations,
+		OnPreCopyRound: mg.onPreCopyRound,
 	}
 }
 
-func (mg *migration) WaitUntilFinished() {
-	<-mg.unblock
+func (mg *migration) WaitUntilFinished() bool {
+	return <-mg.unblock
 }
 
 func (mg *migration) EnsurePathExists() {
@@ -151,12 +568,34 @@ func (mg *migration) EnsurePathExists() {
 type migrationRequestParams struct {
 	podUID         string
 	containerNames []string
+	targetNodeName string
+
+	mode          MigrationMode
+	maxFinalBytes int64
+	maxIterations int32
 }
 
 func getMigrationRequestParams(req *restful.Request) migrationRequestParams {
+	maxFinalBytes, _ := strconv.ParseInt(req.QueryParameter("maxFinalBytes"), 10, 64)
+	maxIterations, _ := strconv.ParseInt(req.QueryParameter("maxIterations"), 10, 32)
+
+	mode := MigrationMode(req.QueryParameter("mode"))
+	if mode == "" {
+		mode = migrationv1alpha1.MigrationModeFull
+	}
+
+	var containerNames []string
+	if raw := req.QueryParameter("containers"); raw != "" {
+		containerNames = strings.Split(raw, ",")
+	}
+
 	return migrationRequestParams{
 		podUID:         req.PathParameter("podUID"),
-		containerNames: strings.Split(req.QueryParameter("containers"), ","),
+		containerNames: containerNames,
+		targetNodeName: req.QueryParameter("targetNode"),
+		mode:           mode,
+		maxFinalBytes:  maxFinalBytes,
+		maxIterations:  int32(maxIterations),
 	}
 }
 
@@ -0,0 +1,110 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newTestManager(maxConcurrent int, mode MigrationQueueMode) *manager {
+	return &manager{
+		migrationQueueMode: mode,
+		migrationSem:       make(chan struct{}, maxConcurrent),
+		migrations:         make(map[types.UID]*migration),
+	}
+}
+
+func TestAcquireMigrationSlotRejectsWhenFull(t *testing.T) {
+	m := newTestManager(1, MigrationQueueReject)
+
+	if err := m.acquireMigrationSlot(context.Background()); err != nil {
+		t.Fatalf("first acquire: unexpected error: %v", err)
+	}
+	defer m.releaseMigrationSlot()
+
+	err := m.acquireMigrationSlot(context.Background())
+	if !errors.Is(err, errTooManyMigrations) {
+		t.Fatalf("second acquire with the slot full = %v, want errTooManyMigrations", err)
+	}
+}
+
+func TestAcquireMigrationSlotBlocksUntilFreed(t *testing.T) {
+	m := newTestManager(1, MigrationQueueBlock)
+
+	if err := m.acquireMigrationSlot(context.Background()); err != nil {
+		t.Fatalf("first acquire: unexpected error: %v", err)
+	}
+
+	acquired := make(chan error, 1)
+	go func() {
+		acquired <- m.acquireMigrationSlot(context.Background())
+	}()
+
+	select {
+	case err := <-acquired:
+		t.Fatalf("blocking acquire returned early (err=%v) before the slot was freed", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	m.releaseMigrationSlot()
+
+	select {
+	case err := <-acquired:
+		if err != nil {
+			t.Fatalf("acquire after release: unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("blocking acquire never returned after the slot was freed")
+	}
+}
+
+func TestAcquireMigrationSlotBlockRespectsContextCancel(t *testing.T) {
+	m := newTestManager(1, MigrationQueueBlock)
+
+	if err := m.acquireMigrationSlot(context.Background()); err != nil {
+		t.Fatalf("first acquire: unexpected error: %v", err)
+	}
+	defer m.releaseMigrationSlot()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := m.acquireMigrationSlot(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("acquire with a cancelled context = %v, want context.Canceled", err)
+	}
+}
+
+func TestMigrationsMapConcurrentAccess(t *testing.T) {
+	m := newTestManager(4, MigrationQueueBlock)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		pod := &v1.Pod{}
+		pod.UID = types.UID(string(rune('a' + i%26)))
+
+		wg.Add(2)
+		go func(pod *v1.Pod) {
+			defer wg.Done()
+			mig := m.newMigration(pod)
+			_ = mig
+		}(pod)
+		go func(pod *v1.Pod) {
+			defer wg.Done()
+			m.FindMigrationForPod(pod)
+		}(pod)
+	}
+	wg.Wait()
+
+	for i := 0; i < 26; i++ {
+		pod := &v1.Pod{}
+		pod.UID = types.UID(string(rune('a' + i)))
+		if _, ok := m.FindMigrationForPod(pod); !ok {
+			t.Fatalf("migration for pod %q not found after concurrent inserts", pod.UID)
+		}
+	}
+}
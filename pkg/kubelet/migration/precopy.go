@@ -0,0 +1,46 @@
+package migration
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	migrationv1alpha1 "k8s.io/kubernetes/pkg/apis/migration/v1alpha1"
+)
+
+// MigrationMode mirrors container.MigrationMode and selects the
+// memory-transfer strategy the runtime uses when checkpointing a
+// container, see migrationv1alpha1.MigrationMode for the semantics of
+// each value.
+type MigrationMode = migrationv1alpha1.MigrationMode
+
+// PreCopyRoundFunc is invoked by the container runtime once per PreCopy
+// pre-dump round so the coordinator can record progress on the Migration
+// object that operators use to tune MaxFinalBytes/MaxIterations.
+type PreCopyRoundFunc func(round int32, bytes int64, dirtyPages int64, duration time.Duration)
+
+// recordPreCopyRound appends a round's statistics to mig's backing
+// Migration object's status.
+func (m *manager) recordPreCopyRound(mig *migration, round int32, bytes int64, dirtyPages int64, duration time.Duration) {
+	if mig.name == "" {
+		return
+	}
+
+	cr, err := m.migrationClient.MigrationV1alpha1().Migrations(mig.namespace).Get(context.TODO(), mig.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) || err != nil {
+		return
+	}
+
+	cr.Status.PreCopyRounds = append(cr.Status.PreCopyRounds, migrationv1alpha1.PreCopyRoundStatus{
+		Round:           round,
+		Bytes:           bytes,
+		DirtyPages:      dirtyPages,
+		DurationSeconds: duration.Seconds(),
+	})
+
+	if _, err := m.migrationClient.MigrationV1alpha1().Migrations(mig.namespace).UpdateStatus(context.TODO(), cr, metav1.UpdateOptions{}); err != nil {
+		return
+	}
+}
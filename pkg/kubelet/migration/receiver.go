@@ -0,0 +1,345 @@
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/klog/v2"
+)
+
+// Restorer runs CRIU restore for a container staged on this node by a
+// Receiver. The concrete implementation lives in kubelet/container,
+// alongside the rest of the runtime-facing container lifecycle code.
+type Restorer interface {
+	RestoreContainer(podUID, containerName, checkpointDir string) error
+}
+
+// Receiver is the target-side counterpart to Transferer: it accepts
+// streamed checkpoints for a pod's containers, stages them under its own
+// migration root, and once all of them have arrived and Finalize is
+// called, restores the pod via restorer.
+type Receiver struct {
+	migrationPath string
+	restorer      Restorer
+
+	// podManager marks the restored pod Ready once restore succeeds.
+	podManager kubepodManager
+
+	// deviceAllocator validates and rewrites device-plugin resource
+	// assignments for restored containers. Nil on nodes that don't expose
+	// a device manager.
+	deviceAllocator DeviceAllocator
+
+	// auth authenticates and authorizes incoming requests against this
+	// Receiver's routes, the same AuthInterface chunk0-7 wired into the
+	// legacy HandleMigrationRequest handler. Checkpoints carry live memory
+	// (secrets, tokens, TLS keys), so the ingest side needs exactly the
+	// same gate as the request side. Nil skips authn/authz (e.g. tests).
+	auth AuthInterface
+}
+
+// kubepodManager is the subset of kubepod.Manager a Receiver needs to
+// flip a restored pod to Ready and resolve the namespace of an in-flight
+// migration for authorization; kept narrow so tests don't need a full
+// kubepod.Manager.
+type kubepodManager interface {
+	MarkPodReady(podUID string)
+	PodNamespace(podUID string) (string, bool)
+}
+
+// NewReceiver builds a Receiver that stages incoming checkpoints under
+// rootPath and restores containers via restorer.
+func NewReceiver(rootPath string, restorer Restorer, podManager kubepodManager, deviceAllocator DeviceAllocator, auth AuthInterface) *Receiver {
+	return &Receiver{
+		migrationPath:   path.Join(rootPath, "migration"),
+		restorer:        restorer,
+		podManager:      podManager,
+		deviceAllocator: deviceAllocator,
+		auth:            auth,
+	}
+}
+
+// authorize authenticates req and, if podUID is non-empty, checks the
+// caller is authorized for verb "migrate" on pods/migration in that pod's
+// namespace; with an empty podUID (the cluster-scoped resource-validation
+// route) it checks the same verb/resource with no namespace or name. It
+// writes a structured 401/403 response and returns false on any failure.
+func (r *Receiver) authorize(req *restful.Request, res *restful.Response, podUID string) bool {
+	if r.auth == nil {
+		return true
+	}
+
+	userInfo, authenticated, err := r.auth.AuthenticateRequest(req.Request)
+	if err != nil || !authenticated {
+		writeMigrationError(res, http.StatusUnauthorized, "Unauthorized", "request could not be authenticated")
+		return false
+	}
+
+	var namespace string
+	if podUID != "" {
+		ns, ok := r.podManager.PodNamespace(podUID)
+		if !ok {
+			writeMigrationError(res, http.StatusNotFound, "PodNotFound", "no pod found with the given UID")
+			return false
+		}
+		namespace = ns
+	}
+
+	attrs := authorizer.AttributesRecord{
+		User:            userInfo,
+		Verb:            "migrate",
+		Namespace:       namespace,
+		Resource:        "pods",
+		Subresource:     "migration",
+		Name:            podUID,
+		ResourceRequest: true,
+	}
+	decision, reason, err := r.auth.Authorize(req.Request.Context(), attrs)
+	if err != nil || decision != authorizer.DecisionAllow {
+		writeMigrationError(res, http.StatusForbidden, "Forbidden", fmt.Sprintf("not authorized to migrate pod %s: %s", podUID, reason))
+		return false
+	}
+	return true
+}
+
+// WebService returns the restful.WebService exposing the receiver's
+// streaming endpoints, to be registered alongside the kubelet's other
+// debugging/management services.
+func (r *Receiver) WebService() *restful.WebService {
+	ws := new(restful.WebService)
+	ws.Path("/migration")
+	ws.Route(ws.POST("/resources/validate").To(r.handleValidateResources))
+	ws.Route(ws.PUT("/{podUID}/containers/{container}").To(r.handleReceiveContainer))
+	ws.Route(ws.PUT("/{podUID}/containers/{container}/resources").To(r.handleReceiveResourceAssignments))
+	ws.Route(ws.POST("/{podUID}/finalize").To(r.handleFinalize))
+	return ws
+}
+
+func (r *Receiver) podDir(podUID string) string {
+	return path.Join(r.migrationPath, podUID)
+}
+
+func (r *Receiver) archivePath(podUID, containerName string) string {
+	return path.Join(r.podDir(podUID), containerName+".tar.gz")
+}
+
+func (r *Receiver) resourcesPath(podUID, containerName string) string {
+	return path.Join(r.podDir(podUID), containerName+".resources.json")
+}
+
+func (r *Receiver) handleValidateResources(req *restful.Request, res *restful.Response) {
+	if !r.authorize(req, res, "") {
+		return
+	}
+
+	var resources map[string]int64
+	if err := json.NewDecoder(req.Request.Body).Decode(&resources); err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if r.deviceAllocator == nil {
+		res.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := r.deviceAllocator.ValidateResources(resources); err != nil {
+		klog.Errorf("cannot satisfy migration resource requirements %v: %v", resources, err)
+		res.WriteHeader(http.StatusInsufficientStorage)
+		return
+	}
+	res.WriteHeader(http.StatusOK)
+}
+
+func (r *Receiver) handleReceiveResourceAssignments(req *restful.Request, res *restful.Response) {
+	podUID := req.PathParameter("podUID")
+	containerName := req.PathParameter("container")
+
+	if err := validatePodUID(podUID); err != nil {
+		writeMigrationError(res, http.StatusBadRequest, "InvalidPodUID", err.Error())
+		return
+	}
+	if err := validateContainerName(containerName); err != nil {
+		writeMigrationError(res, http.StatusBadRequest, "InvalidContainer", err.Error())
+		return
+	}
+
+	if !r.authorize(req, res, podUID) {
+		return
+	}
+
+	resourcesPath := r.resourcesPath(podUID, containerName)
+	if err := os.MkdirAll(path.Dir(resourcesPath), os.FileMode(0777)); err != nil {
+		klog.Errorf("failed to create staging dir for %s/%s resources: %v", podUID, containerName, err)
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	f, err := os.Create(resourcesPath)
+	if err != nil {
+		klog.Errorf("failed to stage resource assignments for %s/%s: %v", podUID, containerName, err)
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, req.Request.Body); err != nil {
+		klog.Errorf("failed to stage resource assignments for %s/%s: %v", podUID, containerName, err)
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	res.WriteHeader(http.StatusOK)
+}
+
+func (r *Receiver) handleReceiveContainer(req *restful.Request, res *restful.Response) {
+	podUID := req.PathParameter("podUID")
+	containerName := req.PathParameter("container")
+	wantDigest := req.HeaderParameter("X-Checkpoint-Digest")
+
+	if err := validatePodUID(podUID); err != nil {
+		writeMigrationError(res, http.StatusBadRequest, "InvalidPodUID", err.Error())
+		return
+	}
+	if err := validateContainerName(containerName); err != nil {
+		writeMigrationError(res, http.StatusBadRequest, "InvalidContainer", err.Error())
+		return
+	}
+
+	if !r.authorize(req, res, podUID) {
+		return
+	}
+
+	archivePath := r.archivePath(podUID, containerName)
+	if err := os.MkdirAll(path.Dir(archivePath), os.FileMode(0777)); err != nil {
+		klog.Errorf("failed to create staging dir for %s/%s: %v", podUID, containerName, err)
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	digest, err := stageArchive(req.Request.Body, archivePath)
+	if err != nil {
+		klog.Errorf("failed to stage checkpoint archive for %s/%s: %v", podUID, containerName, err)
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if wantDigest != "" && digest != wantDigest {
+		os.Remove(archivePath)
+		klog.Errorf("checkpoint archive for %s/%s failed digest verification", podUID, containerName)
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	res.AddHeader("X-Checkpoint-Digest", digest)
+	res.WriteHeader(http.StatusOK)
+}
+
+func (r *Receiver) handleFinalize(req *restful.Request, res *restful.Response) {
+	podUID := req.PathParameter("podUID")
+
+	if err := validatePodUID(podUID); err != nil {
+		writeMigrationError(res, http.StatusBadRequest, "InvalidPodUID", err.Error())
+		return
+	}
+
+	if !r.authorize(req, res, podUID) {
+		return
+	}
+
+	entries, err := readDirNames(r.podDir(podUID))
+	if err != nil {
+		klog.Errorf("failed to list staged archives for pod %s: %v", podUID, err)
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry, ".tar.gz") {
+			// Staged .resources.json files are read by rewriteDevices.
+			continue
+		}
+		containerName := strings.TrimSuffix(entry, ".tar.gz")
+		checkpointDir := path.Join(r.podDir(podUID), containerName)
+
+		if _, err := extractArchive(path.Join(r.podDir(podUID), entry), checkpointDir); err != nil {
+			klog.Errorf("failed to extract checkpoint archive for %s/%s: %v", podUID, containerName, err)
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if err := r.rewriteDevices(podUID, containerName); err != nil {
+			klog.Errorf("device rewrite failed for %s/%s: %v", podUID, containerName, err)
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if err := r.restorer.RestoreContainer(podUID, containerName, path.Join(checkpointDir, checkpointDirName)); err != nil {
+			klog.Errorf("restore failed for %s/%s: %v", podUID, containerName, err)
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	r.podManager.MarkPodReady(podUID)
+	res.WriteHeader(http.StatusOK)
+}
+
+// rewriteDevices points containerName's device cgroup and /dev bind mounts
+// at the device IDs this node's device manager allocates for it, using the
+// assignments the source recorded at checkpoint time. A container that
+// didn't request device-plugin resources has no staged resources.json and
+// is left untouched.
+func (r *Receiver) rewriteDevices(podUID, containerName string) error {
+	if r.deviceAllocator == nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(r.resourcesPath(podUID, containerName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var assignments map[string]ResourceAssignment
+	if err := json.Unmarshal(data, &assignments); err != nil {
+		return err
+	}
+
+	return r.deviceAllocator.RewriteContainerDevices(podUID, containerName, assignments)
+}
+
+func readDirNames(dir string) ([]string, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.Readdirnames(-1)
+}
+
+// stageArchive copies r to archivePath, returning the SHA-256 digest of
+// the bytes written.
+func stageArchive(r io.Reader, archivePath string) (digest string, err error) {
+	f, err := os.Create(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(f, hasher), r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -0,0 +1,83 @@
+package migration
+
+import (
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// ResourceAssignment records one device-plugin resource's allocation for a
+// container: the device IDs allocated, and any env/mount/annotation
+// side-effects the plugin's Allocate response injected, so the target node
+// can recreate an equivalent allocation without re-running admission.
+type ResourceAssignment struct {
+	DeviceIDs   []string          `json:"deviceIDs,omitempty"`
+	Envs        map[string]string `json:"envs,omitempty"`
+	Mounts      []ResourceMount   `json:"mounts,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ResourceMount is a single bind mount a device plugin's Allocate response
+// asked the runtime to add to the container.
+type ResourceMount struct {
+	ContainerPath string `json:"containerPath"`
+	HostPath      string `json:"hostPath"`
+	ReadOnly      bool   `json:"readOnly,omitempty"`
+}
+
+// DeviceAssignmentProvider reads a container's device-plugin allocations
+// (SR-IOV VFs, GPUs, hugepages) from the kubelet device manager's
+// checkpoint, mirroring how multus reads
+// /var/lib/kubelet/device-plugins/kubelet_internal_checkpoint. It is
+// implemented by kubelet/cm/devicemanager.
+type DeviceAssignmentProvider interface {
+	ContainerResourceAssignments(podUID, containerName string) (map[string]ResourceAssignment, error)
+}
+
+// DeviceAllocator is the target-node counterpart to
+// DeviceAssignmentProvider: it checks whether this node's device manager can
+// satisfy a set of resource requirements, and rewrites a restored
+// container's device cgroup and /dev bind mounts to point at the device IDs
+// it allocates locally. It is implemented by kubelet/cm/devicemanager.
+type DeviceAllocator interface {
+	ValidateResources(resources map[string]int64) error
+	RewriteContainerDevices(podUID, containerName string, assignments map[string]ResourceAssignment) error
+}
+
+// extendedResourceRequirements sums each device-plugin resource a pod's
+// containers request, so the source can ask the target to validate it has
+// enough of each before any checkpoint is taken.
+func extendedResourceRequirements(pod *v1.Pod) map[string]int64 {
+	reqs := map[string]int64{}
+	for _, c := range pod.Spec.Containers {
+		for name, qty := range c.Resources.Requests {
+			if !isExtendedResourceName(name) {
+				continue
+			}
+			reqs[string(name)] += qty.Value()
+		}
+	}
+	return reqs
+}
+
+// isExtendedResourceName reports whether name is a device-plugin resource
+// (e.g. nvidia.com/gpu) rather than a native compute resource.
+func isExtendedResourceName(name v1.ResourceName) bool {
+	return strings.Contains(string(name), "/") && !strings.HasPrefix(string(name), "kubernetes.io/")
+}
+
+// ValidateTargetResources fails fast, before any checkpoint is taken, if
+// targetNode cannot satisfy the device-plugin resources pod's containers
+// are currently holding.
+func (m *manager) ValidateTargetResources(pod *v1.Pod, targetNode string) error {
+	reqs := extendedResourceRequirements(pod)
+	if len(reqs) == 0 || m.transferer == nil {
+		return nil
+	}
+
+	addr, err := m.transferer.nodeAddress(targetNode)
+	if err != nil {
+		return err
+	}
+	return m.transferer.ValidateResources(addr, reqs)
+}
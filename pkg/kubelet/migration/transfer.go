@@ -0,0 +1,195 @@
+package migration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"k8s.io/klog/v2"
+)
+
+// NodeAddressFunc resolves a node name to the address its kubelet's
+// migration Receiver is reachable at (host:port).
+type NodeAddressFunc func(nodeName string) (string, error)
+
+// BearerTokenFunc returns the credential this kubelet presents to a peer's
+// Receiver, checked there the same way Receiver.authorize checks any other
+// caller (authenticator.Request). It's a func, not a static string, so a
+// token that's rotated (e.g. a bound service account token) is re-read on
+// every call rather than captured once at startup.
+type BearerTokenFunc func() (string, error)
+
+// Transferer streams checkpoint archives to a peer kubelet's Receiver and
+// waits for it to confirm the pod has been restored there before the
+// source kubelet is allowed to tear the pod down.
+type Transferer struct {
+	client      *http.Client
+	nodeAddress NodeAddressFunc
+	bearerToken BearerTokenFunc
+}
+
+// NewTransferer builds a Transferer that resolves target kubelets via
+// resolveNodeAddress and authenticates to their Receiver with the token
+// bearerToken returns.
+func NewTransferer(resolveNodeAddress NodeAddressFunc, bearerToken BearerTokenFunc) *Transferer {
+	return &Transferer{
+		client:      http.DefaultClient,
+		nodeAddress: resolveNodeAddress,
+		bearerToken: bearerToken,
+	}
+}
+
+// newRequest builds an HTTP request carrying this Transferer's bearer
+// token, so every outbound call authenticates the same way against a
+// Receiver that has auth configured.
+func (t *Transferer) newRequest(method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if t.bearerToken != nil {
+		token, err := t.bearerToken()
+		if err != nil {
+			return nil, fmt.Errorf("getting bearer token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req, nil
+}
+
+// TransferPod streams every container's checkpoint archive in result to
+// the Receiver running on targetNode, then asks it to finalize (restore)
+// the pod. It returns once the target has confirmed the pod is Ready, or
+// with an error if the transfer or restore failed.
+func (t *Transferer) TransferPod(targetNode string, podUID string, result Result) error {
+	addr, err := t.nodeAddress(targetNode)
+	if err != nil {
+		return fmt.Errorf("resolving address for node %s: %w", targetNode, err)
+	}
+
+	for name, rc := range result.Containers {
+		if err := t.sendContainer(addr, podUID, name, rc.ArchivePath, rc.Digest); err != nil {
+			return fmt.Errorf("transferring container %s: %w", name, err)
+		}
+		if len(rc.ResourceAssignments) > 0 {
+			if err := t.sendResourceAssignments(addr, podUID, name, rc.ResourceAssignments); err != nil {
+				return fmt.Errorf("transferring resource assignments for %s: %w", name, err)
+			}
+		}
+		klog.V(3).Infof("transferred checkpoint archive for %s/%s to %s (sha256:%s)", podUID, name, targetNode, rc.Digest)
+	}
+
+	return t.finalize(addr, podUID)
+}
+
+// ValidateResources asks the Receiver at addr whether its node's device
+// manager can currently satisfy resources, returning an error describing
+// the shortfall if not. Called before any checkpoint is taken so a
+// migration that can't land anywhere fails fast.
+func (t *Transferer) ValidateResources(addr string, resources map[string]int64) error {
+	body, err := json.Marshal(resources)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("http://%s/migration/resources/validate", addr)
+	req, err := t.newRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("target cannot satisfy resource requirements: %s", resp.Status)
+	}
+	return nil
+}
+
+// sendResourceAssignments hands the target the device-plugin allocations
+// (device IDs, env/mount/annotation side-effects) the source recorded for
+// containerName, so the target can rewrite the restored container's device
+// cgroup and /dev mounts before restore.
+func (t *Transferer) sendResourceAssignments(addr, podUID, containerName string, assignments map[string]ResourceAssignment) error {
+	body, err := json.Marshal(assignments)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("http://%s/migration/%s/containers/%s/resources", addr, podUID, containerName)
+	req, err := t.newRequest(http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("receiver returned %s", resp.Status)
+	}
+	return nil
+}
+
+// sendContainer streams the checkpoint archive at archivePath to the
+// target's receive endpoint, asserting that the target computed the same
+// digest we did when we built the archive.
+func (t *Transferer) sendContainer(addr, podUID, containerName, archivePath, digest string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	url := fmt.Sprintf("http://%s/migration/%s/containers/%s", addr, podUID, containerName)
+	req, err := t.newRequest(http.MethodPut, url, f)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	req.Header.Set("X-Checkpoint-Digest", digest)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("receiver returned %s", resp.Status)
+	}
+	if got := resp.Header.Get("X-Checkpoint-Digest"); got != digest {
+		return fmt.Errorf("digest mismatch: sent sha256:%s, receiver stored sha256:%s", digest, got)
+	}
+	return nil
+}
+
+// finalize tells the target to restore the pod from the archives it has
+// received, blocking until the target reports the outcome.
+func (t *Transferer) finalize(addr, podUID string) error {
+	url := fmt.Sprintf("http://%s/migration/%s/finalize", addr, podUID)
+	req, err := t.newRequest(http.MethodPost, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("target failed to restore pod %s: %s", podUID, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package migration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransfererValidateResourcesSendsBearerToken(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotAuth = req.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	transferer := NewTransferer(
+		func(string) (string, error) { return srv.Listener.Addr().String(), nil },
+		func() (string, error) { return "test-token", nil },
+	)
+
+	if err := transferer.ValidateResources(srv.Listener.Addr().String(), map[string]int64{"example.com/gpu": 1}); err != nil {
+		t.Fatalf("ValidateResources returned unexpected error: %v", err)
+	}
+	if want := "Bearer test-token"; gotAuth != want {
+		t.Fatalf("Authorization header = %q, want %q", gotAuth, want)
+	}
+}
+
+func TestTransfererValidateResourcesWithoutBearerTokenFunc(t *testing.T) {
+	var gotAuth string
+	sawHeader := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotAuth, sawHeader = req.Header.Get("Authorization"), req.Header.Get("Authorization") != ""
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	transferer := NewTransferer(func(string) (string, error) { return srv.Listener.Addr().String(), nil }, nil)
+
+	if err := transferer.ValidateResources(srv.Listener.Addr().String(), nil); err != nil {
+		t.Fatalf("ValidateResources returned unexpected error: %v", err)
+	}
+	if sawHeader {
+		t.Fatalf("Authorization header = %q, want none with a nil BearerTokenFunc", gotAuth)
+	}
+}